@@ -0,0 +1,22 @@
+package azurerm
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider for azurerm.
+//
+// NOTE: this file only carries the ResourcesMap entries touched by the
+// Media Services work in this tree - the rest of the provider's schema,
+// config and hundreds of other resources live outside this checkout.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"azurerm_media_services":          resourceArmMediaServices(),
+			"azurerm_media_asset":             resourceArmMediaAsset(),
+			"azurerm_media_transform":         resourceArmMediaTransform(),
+			"azurerm_media_streaming_locator": resourceArmMediaStreamingLocator(),
+		},
+	}
+}
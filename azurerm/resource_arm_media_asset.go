@@ -0,0 +1,179 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/Azure/azure-sdk-for-go/services/mediaservices/mgmt/2018-07-01/media"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmMediaAsset() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmMediaAssetCreateUpdate,
+		Read:   resourceArmMediaAssetRead,
+		Update: resourceArmMediaAssetCreateUpdate,
+		Delete: resourceArmMediaAssetDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceArmMediaAssetImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[-a-zA-Z0-9]{1,63}$"),
+					"Media Asset name must be 1 - 63 characters long, contain only letters, numbers and hyphens.",
+				),
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"media_services_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"alternate_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"asset_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"container": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"storage_account_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmMediaAssetCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	resourceGroup := d.Get("resource_group_name").(string)
+	accountName := d.Get("media_services_account_name").(string)
+	client := meta.(*ArmClient).mediaAssetsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+
+	parameters := media.Asset{
+		AssetProperties: &media.AssetProperties{
+			Description: utils.String(d.Get("description").(string)),
+			AlternateID: utils.String(d.Get("alternate_id").(string)),
+		},
+	}
+
+	asset, err := client.CreateOrUpdate(ctx, resourceGroup, accountName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating Media Asset %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	d.SetId(*asset.ID)
+
+	return resourceArmMediaAssetRead(d, meta)
+}
+
+func resourceArmMediaAssetRead(d *schema.ResourceData, meta interface{}) error {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	resourceGroup := id.ResourceGroup
+	accountName := id.Path["mediaservices"]
+	name := id.Path["assets"]
+
+	client := meta.(*ArmClient).mediaAssetsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resp, err := client.Get(ctx, resourceGroup, accountName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Media Asset %q (Media Services Account %q / Resource Group %q) was not found - removing from state", name, accountName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Media Asset %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("media_services_account_name", accountName)
+
+	if props := resp.AssetProperties; props != nil {
+		d.Set("description", props.Description)
+		d.Set("alternate_id", props.AlternateID)
+		d.Set("container", props.Container)
+		d.Set("storage_account_name", props.StorageAccountName)
+
+		if props.AssetID != nil {
+			d.Set("asset_id", props.AssetID.String())
+		}
+	}
+
+	return nil
+}
+
+func resourceArmMediaAssetDelete(d *schema.ResourceData, meta interface{}) error {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	resourceGroup := id.ResourceGroup
+	accountName := id.Path["mediaservices"]
+	name := id.Path["assets"]
+
+	client := meta.(*ArmClient).mediaAssetsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resp, err := client.Delete(ctx, resourceGroup, accountName, name)
+	if err != nil {
+		if response.WasNotFound(resp) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Media Asset %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func resourceArmMediaAssetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	if _, ok := id.Path["mediaservices"]; !ok {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: expected segment 'mediaservices'", d.Id())
+	}
+
+	if _, ok := id.Path["assets"]; !ok {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: expected segment 'assets'", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -1,17 +1,46 @@
 package azurerm
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/mediaservices/mgmt/2018-07-01/media"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+// resourceArmMediaServicesCustomizeDiff enforces, at plan time, the
+// "exactly one primary storage account" rule, which used to only surface at
+// apply time - see validateMediaServicesStorageAccounts
+// for the deeper (live-API) validation that still has to happen at apply
+// time.
+func resourceArmMediaServicesCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	storageAccounts := diff.Get("storage_account").(*schema.Set).List()
+	return validateExactlyOnePrimaryStorageAccount(storageAccounts)
+}
+
+func validateExactlyOnePrimaryStorageAccount(storageAccounts []interface{}) error {
+	primaryCount := 0
+	for _, accountMapRaw := range storageAccounts {
+		accountMap := accountMapRaw.(map[string]interface{})
+		if accountMap["is_primary"].(bool) {
+			primaryCount++
+		}
+	}
+
+	if primaryCount != 1 {
+		return fmt.Errorf("exactly one `storage_account` block must have `is_primary = true` (found %d)", primaryCount)
+	}
+
+	return nil
+}
+
 /*
  Example terraform template:
 
@@ -47,6 +76,14 @@ func resourceArmMediaServices() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceArmMediaServicesCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		// TODO: Add validation after finding out the rules for AMS names
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -82,13 +119,50 @@ func resourceArmMediaServices() *schema.Resource {
 					},
 				},
 			},
+
+			"identity": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(media.SystemAssigned),
+							}, false),
+						},
+
+						"principal_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tenant_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"media_service_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"primary_storage_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func resourceArmMediaServicesCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	client := meta.(*ArmClient).mediaServicesClient
+	client := mediaServicesClientWithPolling(meta.(*ArmClient).mediaServicesClient)
 	ctx := meta.(*ArmClient).StopContext
 
 	accountName := d.Get("name").(string)
@@ -96,8 +170,9 @@ func resourceArmMediaServicesCreateUpdate(d *schema.ResourceData, meta interface
 	tags := d.Get("tags").(map[string]interface{})
 	resourceGroup := d.Get("resource_group_name").(string)
 
-	storageAccounts, err := expandAzureRmStorageAccounts(d)
-	if err != nil {
+	storageAccounts := expandAzureRmStorageAccounts(d)
+
+	if err := validateMediaServicesStorageAccounts(ctx, meta.(*ArmClient).storageServiceClient, location, storageAccounts); err != nil {
 		return err
 	}
 
@@ -105,11 +180,24 @@ func resourceArmMediaServicesCreateUpdate(d *schema.ResourceData, meta interface
 		ServiceProperties: &media.ServiceProperties{
 			StorageAccounts: &storageAccounts,
 		},
+		Identity: expandAzureRmMediaServicesIdentity(d),
 		Location: utils.String(location),
 		Tags:     expandTags(tags),
 	}
 
-	service, err := client.CreateOrUpdate(ctx, resourceGroup, accountName, parameters)
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var service media.Service
+	err := withMediaServicesInterruptHandling(waitCtx, func() error {
+		var createErr error
+		service, createErr = client.CreateOrUpdate(waitCtx, resourceGroup, accountName, parameters)
+		return createErr
+	})
 	if err != nil {
 		return fmt.Errorf("Error creating Media Service Account: %+v", err)
 	}
@@ -147,6 +235,19 @@ func resourceArmMediaServicesRead(d *schema.ResourceData, meta interface{}) erro
 	if location := resp.Location; location != nil {
 		d.Set("location", azureRMNormalizeLocation(*location))
 	}
+
+	if props := resp.ServiceProperties; props != nil {
+		if props.MediaServiceID != nil {
+			d.Set("media_service_id", props.MediaServiceID.String())
+		}
+
+		d.Set("primary_storage_account_id", flattenAzureRmMediaServicesPrimaryStorageAccount(props.StorageAccounts))
+	}
+
+	if err := d.Set("identity", flattenAzureRmMediaServicesIdentity(resp.Identity)); err != nil {
+		return fmt.Errorf("Error setting `identity`: %+v", err)
+	}
+
 	flattenAndSetTags(d, resp.Tags)
 
 	return nil
@@ -154,7 +255,7 @@ func resourceArmMediaServicesRead(d *schema.ResourceData, meta interface{}) erro
 
 func resourceArmMediaServicesDelete(d *schema.ResourceData, meta interface{}) error {
 
-	client := meta.(*ArmClient).mediaServicesClient
+	client := mediaServicesClientWithPolling(meta.(*ArmClient).mediaServicesClient)
 	ctx := meta.(*ArmClient).StopContext
 
 	id, err := parseAzureResourceID(d.Id())
@@ -165,7 +266,15 @@ func resourceArmMediaServicesDelete(d *schema.ResourceData, meta interface{}) er
 	name := id.Path["mediaservices"]
 	resourceGroup := id.ResourceGroup
 
-	httpResponse, err := client.Delete(ctx, resourceGroup, name)
+	waitCtx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	var httpResponse autorest.Response
+	err = withMediaServicesInterruptHandling(waitCtx, func() error {
+		var deleteErr error
+		httpResponse, deleteErr = client.Delete(waitCtx, resourceGroup, name)
+		return deleteErr
+	})
 	if err != nil {
 		if response.WasNotFound(httpResponse.Response) {
 			return nil
@@ -176,13 +285,10 @@ func resourceArmMediaServicesDelete(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func expandAzureRmStorageAccounts(d *schema.ResourceData) ([]media.StorageAccount, error) {
+func expandAzureRmStorageAccounts(d *schema.ResourceData) []media.StorageAccount {
 	storageAccounts := d.Get("storage_account").(*schema.Set).List()
 	rules := make([]media.StorageAccount, 0)
 
-	// Only one storage account can be primary
-	primaryAssigned := false
-
 	for _, accountMapRaw := range storageAccounts {
 		accountMap := accountMapRaw.(map[string]interface{})
 
@@ -191,13 +297,6 @@ func expandAzureRmStorageAccounts(d *schema.ResourceData) ([]media.StorageAccoun
 		storageType := media.Secondary
 		if accountMap["is_primary"].(bool) {
 			storageType = media.Primary
-
-			// TODO: This function shouldn't process storage accounts and validate them. Move logic out appropriately.
-			if primaryAssigned {
-				return nil, fmt.Errorf("Error processing storage account '%s'. Another storage account is already assigned as is_primary = 'true'", id)
-			}
-
-			primaryAssigned = true
 		}
 
 		storageAccount := media.StorageAccount{
@@ -208,5 +307,56 @@ func expandAzureRmStorageAccounts(d *schema.ResourceData) ([]media.StorageAccoun
 		rules = append(rules, storageAccount)
 	}
 
-	return rules, nil
+	return rules
+}
+
+func expandAzureRmMediaServicesIdentity(d *schema.ResourceData) *media.ServiceIdentity {
+	identities := d.Get("identity").([]interface{})
+	if len(identities) == 0 {
+		return nil
+	}
+
+	identity := identities[0].(map[string]interface{})
+
+	return &media.ServiceIdentity{
+		Type: media.ManagedIdentityType(identity["type"].(string)),
+	}
+}
+
+func flattenAzureRmMediaServicesIdentity(identity *media.ServiceIdentity) []interface{} {
+	if identity == nil {
+		return []interface{}{}
+	}
+
+	principalID := ""
+	if identity.PrincipalID != nil {
+		principalID = identity.PrincipalID.String()
+	}
+
+	tenantID := ""
+	if identity.TenantID != nil {
+		tenantID = identity.TenantID.String()
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         string(identity.Type),
+			"principal_id": principalID,
+			"tenant_id":    tenantID,
+		},
+	}
+}
+
+func flattenAzureRmMediaServicesPrimaryStorageAccount(storageAccounts *[]media.StorageAccount) string {
+	if storageAccounts == nil {
+		return ""
+	}
+
+	for _, account := range *storageAccounts {
+		if account.Type == media.Primary && account.ID != nil {
+			return *account.ID
+		}
+	}
+
+	return ""
 }
@@ -0,0 +1,155 @@
+package azurerm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/mediaservices/mgmt/2018-07-01/media"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// mediaServicesPollMinInterval and mediaServicesPollMaxInterval are vars
+// rather than consts so tests can shrink them instead of sleeping in real
+// time.
+var (
+	mediaServicesPollMinInterval = 5 * time.Second
+	mediaServicesPollMaxInterval = 30 * time.Second
+)
+
+// mediaServicesOperationStatus mirrors the subset of the Azure-AsyncOperation
+// response body that we care about when polling a long running Media
+// Services operation to completion.
+type mediaServicesOperationStatus struct {
+	Status string `json:"status"`
+	Error  *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// withMediaServicesAsyncPoll returns an autorest.SendDecorator which, once
+// the wrapped sender returns, follows any Azure-AsyncOperation (falling back
+// to Location) header on the response and blocks until the underlying
+// operation reaches a terminal state. This lets Create, Update and Delete
+// share a single implementation of "wait for the account to actually be
+// ready" rather than each reimplementing it.
+func withMediaServicesAsyncPoll() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			resp, err := s.Do(r)
+			if err != nil {
+				return resp, err
+			}
+
+			opURL := mediaServicesAsyncOperationURL(resp)
+			if opURL == "" {
+				return resp, nil
+			}
+
+			if err := pollMediaServicesOperation(r.Context(), s, opURL, r.Header); err != nil {
+				return resp, err
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+func mediaServicesAsyncOperationURL(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+
+	if url := resp.Header.Get("Azure-AsyncOperation"); url != "" {
+		return url
+	}
+
+	return resp.Header.Get("Location")
+}
+
+// pollMediaServicesOperation polls the given async operation URL with a
+// capped exponential backoff (5s -> 30s) until it reaches a terminal status,
+// the operation disappears (treated as done) or ctx is cancelled - which
+// happens both on a context-timeout and when the user Ctrl-C's out of
+// `terraform apply` via withMediaServicesInterruptHandling.
+func pollMediaServicesOperation(ctx context.Context, sender autorest.Sender, operationURL string, headers http.Header) error {
+	interval := mediaServicesPollMinInterval
+
+	for {
+		req, err := http.NewRequest(http.MethodGet, operationURL, nil)
+		if err != nil {
+			return fmt.Errorf("Error building Media Services async operation request: %+v", err)
+		}
+		req = req.WithContext(ctx)
+		req.Header = headers.Clone()
+
+		resp, err := sender.Do(req)
+		if err != nil {
+			return fmt.Errorf("Error polling Media Services async operation: %+v", err)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return nil
+		}
+
+		var status mediaServicesOperationStatus
+		decodeErr := json.NewDecoder(resp.Body).Decode(&status)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return fmt.Errorf("Error parsing Media Services async operation response: %+v", decodeErr)
+		}
+
+		switch status.Status {
+		case "Succeeded":
+			return nil
+		case "Failed", "Canceled":
+			if status.Error != nil {
+				return fmt.Errorf("Media Services async operation failed with status %q: %s (%s)", status.Status, status.Error.Message, status.Error.Code)
+			}
+			return fmt.Errorf("Media Services async operation finished with status %q", status.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Timed out waiting for Media Services async operation: %+v", ctx.Err())
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > mediaServicesPollMaxInterval {
+			interval = mediaServicesPollMaxInterval
+		}
+	}
+}
+
+// mediaServicesClientWithPolling decorates the given client's sender with
+// withMediaServicesAsyncPoll so that callers of Create, Update and Delete
+// all wait for the underlying long running operation to finish.
+func mediaServicesClientWithPolling(client media.ServicesClient) media.ServicesClient {
+	client.Client.Sender = autorest.DecorateSender(client.Client.Sender, withMediaServicesAsyncPoll())
+	return client
+}
+
+// withMediaServicesInterruptHandling runs fn in a goroutine and races it
+// against ctx being cancelled, which happens when the user hits Ctrl-C
+// during `terraform apply` (d.StopContext/meta.(*ArmClient).StopContext is
+// cancelled by the SDK's signal handling). This keeps a cancelled poll from
+// hanging the provider and instead surfaces a clean error.
+func withMediaServicesInterruptHandling(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("Media Services operation cancelled: %+v", ctx.Err())
+	case err := <-done:
+		return err
+	}
+}
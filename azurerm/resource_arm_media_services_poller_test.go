@@ -0,0 +1,149 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/mediaservices/mgmt/2018-07-01/media"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestPollMediaServicesOperation_succeeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "Succeeded"}`)
+	}))
+	defer server.Close()
+
+	err := pollMediaServicesOperation(context.Background(), autorest.SenderFunc(server.Client().Do), server.URL, http.Header{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %+v", err)
+	}
+}
+
+func TestPollMediaServicesOperation_notFoundIsDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := pollMediaServicesOperation(context.Background(), autorest.SenderFunc(server.Client().Do), server.URL, http.Header{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %+v", err)
+	}
+}
+
+func TestPollMediaServicesOperation_failedSurfacesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "Failed", "error": {"code": "Conflict", "message": "storage role assignment failed"}}`)
+	}))
+	defer server.Close()
+
+	err := pollMediaServicesOperation(context.Background(), autorest.SenderFunc(server.Client().Do), server.URL, http.Header{})
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if got := err.Error(); !strings.Contains(got, "Conflict") || !strings.Contains(got, "storage role assignment failed") {
+		t.Fatalf("expected error to surface the Failed status code/message, got: %q", got)
+	}
+}
+
+func TestPollMediaServicesOperation_retriesUntilTerminalStatus(t *testing.T) {
+	oldMin, oldMax := mediaServicesPollMinInterval, mediaServicesPollMaxInterval
+	mediaServicesPollMinInterval = time.Millisecond
+	mediaServicesPollMaxInterval = 4 * time.Millisecond
+	defer func() {
+		mediaServicesPollMinInterval, mediaServicesPollMaxInterval = oldMin, oldMax
+	}()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			fmt.Fprint(w, `{"status": "Running"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": "Succeeded"}`)
+	}))
+	defer server.Close()
+
+	err := pollMediaServicesOperation(context.Background(), autorest.SenderFunc(server.Client().Do), server.URL, http.Header{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %+v", err)
+	}
+	if requests != 3 {
+		t.Fatalf("expected the poller to retry until a terminal status, got %d requests", requests)
+	}
+}
+
+func TestPollMediaServicesOperation_forwardsRequestHeaders(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"status": "Succeeded"}`)
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer test-token")
+
+	err := pollMediaServicesOperation(context.Background(), autorest.SenderFunc(server.Client().Do), server.URL, headers)
+	if err != nil {
+		t.Fatalf("expected no error, got: %+v", err)
+	}
+	if gotAuth != "Bearer test-token" {
+		t.Fatalf("expected the poll request to carry the original Authorization header, got %q", gotAuth)
+	}
+}
+
+// TestMediaServicesClientWithPolling_createOrUpdateWaitsForAsyncOperation
+// exercises the same client wiring resourceArmMediaServicesCreateUpdate and
+// resourceArmMediaServicesDelete use - a real media.ServicesClient decorated
+// by mediaServicesClientWithPolling - rather than calling
+// pollMediaServicesOperation directly, so a break in how the two are wired
+// together would fail this test too.
+func TestMediaServicesClientWithPolling_createOrUpdateWaitsForAsyncOperation(t *testing.T) {
+	oldMin, oldMax := mediaServicesPollMinInterval, mediaServicesPollMaxInterval
+	mediaServicesPollMinInterval = time.Millisecond
+	mediaServicesPollMaxInterval = 4 * time.Millisecond
+	defer func() {
+		mediaServicesPollMinInterval, mediaServicesPollMaxInterval = oldMin, oldMax
+	}()
+
+	var operationRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.Header().Set("Azure-AsyncOperation", fmt.Sprintf("http://%s/operation", r.Host))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Media/mediaservices/acct1", "name": "acct1"}`)
+			return
+		}
+
+		operationRequests++
+		if operationRequests < 2 {
+			fmt.Fprint(w, `{"status": "Running"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": "Succeeded"}`)
+	}))
+	defer server.Close()
+
+	client := media.NewServicesClientWithBaseURI(server.URL, "sub1")
+	client.Client.Sender = server.Client()
+	polled := mediaServicesClientWithPolling(client)
+
+	service, err := polled.CreateOrUpdate(context.Background(), "rg1", "acct1", media.Service{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %+v", err)
+	}
+	if service.ID == nil || *service.ID == "" {
+		t.Fatal("expected CreateOrUpdate to return the created Media Service")
+	}
+	if operationRequests < 2 {
+		t.Fatalf("expected CreateOrUpdate to block on the async operation until it reached a terminal status, got %d poll requests", operationRequests)
+	}
+}
@@ -0,0 +1,57 @@
+package azurerm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateExactlyOnePrimaryStorageAccount(t *testing.T) {
+	cases := []struct {
+		name     string
+		accounts []interface{}
+		wantErr  string
+	}{
+		{
+			name: "exactly one primary",
+			accounts: []interface{}{
+				map[string]interface{}{"id": "acct1", "is_primary": true},
+				map[string]interface{}{"id": "acct2", "is_primary": false},
+			},
+		},
+		{
+			name: "no primary",
+			accounts: []interface{}{
+				map[string]interface{}{"id": "acct1", "is_primary": false},
+			},
+			wantErr: "found 0",
+		},
+		{
+			name: "two primaries",
+			accounts: []interface{}{
+				map[string]interface{}{"id": "acct1", "is_primary": true},
+				map[string]interface{}{"id": "acct2", "is_primary": true},
+			},
+			wantErr: "found 2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateExactlyOnePrimaryStorageAccount(tc.accounts)
+
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %+v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected an error containing %q, got none", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error to contain %q, got: %+v", tc.wantErr, err)
+			}
+		})
+	}
+}
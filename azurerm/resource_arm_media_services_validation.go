@@ -0,0 +1,75 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/mediaservices/mgmt/2018-07-01/media"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2018-07-01/storage"
+	"github.com/hashicorp/go-multierror"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// mediaServicesSupportedStorageSkus are the storage account SKUs that the
+// Media Services API will actually accept - Premium and zone-redundant SKUs
+// are rejected by ARM with an opaque error, so we catch them here instead.
+var mediaServicesSupportedStorageSkus = map[storage.SkuName]struct{}{
+	storage.StandardLRS:   {},
+	storage.StandardGRS:   {},
+	storage.StandardRAGRS: {},
+}
+
+// validateMediaServicesStorageAccounts checks each referenced storage
+// account against the live API rather than relying on ARM to reject an
+// invalid CreateOrUpdate wholesale: every `id` must actually be a
+// Microsoft.Storage/storageAccounts resource, the account must exist, it
+// must live in the same region as the Media Services account, and it must
+// use a SKU Media Services supports. Problems are aggregated with
+// go-multierror so a user fixing one typo isn't stuck replanning for the
+// next.
+func validateMediaServicesStorageAccounts(ctx context.Context, client storage.AccountsClient, location string, storageAccounts []media.StorageAccount) error {
+	var result *multierror.Error
+
+	for _, account := range storageAccounts {
+		if account.ID == nil {
+			continue
+		}
+		accountID := *account.ID
+
+		id, err := parseAzureResourceID(accountID)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("Error parsing Storage Account ID %q: %+v", accountID, err))
+			continue
+		}
+
+		name, ok := id.Path["storageAccounts"]
+		if !ok || !strings.EqualFold(id.Provider, "Microsoft.Storage") {
+			result = multierror.Append(result, fmt.Errorf("%q is not a Microsoft.Storage/storageAccounts resource ID", accountID))
+			continue
+		}
+
+		resp, err := client.GetProperties(ctx, id.ResourceGroup, name, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				result = multierror.Append(result, fmt.Errorf("Storage Account %q (Resource Group %q) was not found", name, id.ResourceGroup))
+				continue
+			}
+
+			result = multierror.Append(result, fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q): %+v", name, id.ResourceGroup, err))
+			continue
+		}
+
+		if resp.Location != nil && !strings.EqualFold(*resp.Location, location) {
+			result = multierror.Append(result, fmt.Errorf("Storage Account %q (Resource Group %q) is in region %q, but the Media Services Account is in %q - they must match", name, id.ResourceGroup, *resp.Location, location))
+		}
+
+		if sku := resp.Sku; sku != nil {
+			if _, supported := mediaServicesSupportedStorageSkus[sku.Name]; !supported {
+				result = multierror.Append(result, fmt.Errorf("Storage Account %q (Resource Group %q) uses SKU %q, which is not supported by Media Services - use Standard_LRS, Standard_GRS or Standard_RAGRS", name, id.ResourceGroup, sku.Name))
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
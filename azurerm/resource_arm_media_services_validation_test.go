@@ -0,0 +1,126 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/mediaservices/mgmt/2018-07-01/media"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2018-07-01/storage"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func testStorageAccountsClient(handler http.HandlerFunc) (storage.AccountsClient, func()) {
+	server := httptest.NewServer(handler)
+
+	client := storage.NewAccountsClientWithBaseURI(server.URL, "00000000-0000-0000-0000-000000000000")
+	client.Client.Sender = server.Client()
+	return client, server.Close
+}
+
+func TestValidateMediaServicesStorageAccounts(t *testing.T) {
+	cases := []struct {
+		name      string
+		accountID string
+		handler   http.HandlerFunc
+		location  string
+		wantErr   string
+	}{
+		{
+			name:      "valid account",
+			accountID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/acct1",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"location": "westus", "sku": {"name": "Standard_LRS"}}`)
+			},
+			location: "westus",
+		},
+		{
+			name:      "not a storage account resource ID",
+			accountID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1",
+			handler:   func(w http.ResponseWriter, r *http.Request) {},
+			location:  "westus",
+			wantErr:   "is not a Microsoft.Storage/storageAccounts resource ID",
+		},
+		{
+			name:      "account not found",
+			accountID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/missing",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			location: "westus",
+			wantErr:  "was not found",
+		},
+		{
+			name:      "region mismatch",
+			accountID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/acct1",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"location": "eastus", "sku": {"name": "Standard_LRS"}}`)
+			},
+			location: "westus",
+			wantErr:  "they must match",
+		},
+		{
+			name:      "unsupported sku",
+			accountID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/acct1",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"location": "westus", "sku": {"name": "Premium_LRS"}}`)
+			},
+			location: "westus",
+			wantErr:  "not supported by Media Services",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, closeServer := testStorageAccountsClient(tc.handler)
+			defer closeServer()
+
+			storageAccounts := []media.StorageAccount{
+				{ID: utils.String(tc.accountID)},
+			}
+
+			err := validateMediaServicesStorageAccounts(context.Background(), client, tc.location, storageAccounts)
+
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %+v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected an error containing %q, got none", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error to contain %q, got: %+v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateMediaServicesStorageAccounts_aggregatesMultipleProblems(t *testing.T) {
+	badID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1"
+	missingID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/missing"
+
+	client, closeServer := testStorageAccountsClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer closeServer()
+
+	storageAccounts := []media.StorageAccount{
+		{ID: utils.String(badID)},
+		{ID: utils.String(missingID)},
+	}
+
+	err := validateMediaServicesStorageAccounts(context.Background(), client, "westus", storageAccounts)
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+
+	if !strings.Contains(err.Error(), "is not a Microsoft.Storage/storageAccounts resource ID") || !strings.Contains(err.Error(), "was not found") {
+		t.Fatalf("expected both problems to be aggregated, got: %+v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/mediaservices/mgmt/2018-07-01/media"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmMediaStreamingLocator() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmMediaStreamingLocatorCreate,
+		Read:   resourceArmMediaStreamingLocatorRead,
+		Delete: resourceArmMediaStreamingLocatorDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceArmMediaStreamingLocatorImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"media_services_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"asset_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"streaming_policy_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"streaming_locator_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"streaming_urls": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceArmMediaStreamingLocatorCreate(d *schema.ResourceData, meta interface{}) error {
+	resourceGroup := d.Get("resource_group_name").(string)
+	accountName := d.Get("media_services_account_name").(string)
+	client := meta.(*ArmClient).mediaStreamingLocatorsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+
+	parameters := media.StreamingLocator{
+		StreamingLocatorProperties: &media.StreamingLocatorProperties{
+			AssetName:           utils.String(d.Get("asset_name").(string)),
+			StreamingPolicyName: utils.String(d.Get("streaming_policy_name").(string)),
+		},
+	}
+
+	locator, err := client.Create(ctx, resourceGroup, accountName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating Media Streaming Locator %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	d.SetId(*locator.ID)
+
+	return resourceArmMediaStreamingLocatorRead(d, meta)
+}
+
+func resourceArmMediaStreamingLocatorRead(d *schema.ResourceData, meta interface{}) error {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	resourceGroup := id.ResourceGroup
+	accountName := id.Path["mediaservices"]
+	name := id.Path["streamingLocators"]
+
+	client := meta.(*ArmClient).mediaStreamingLocatorsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resp, err := client.Get(ctx, resourceGroup, accountName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Media Streaming Locator %q (Media Services Account %q / Resource Group %q) was not found - removing from state", name, accountName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Media Streaming Locator %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("media_services_account_name", accountName)
+
+	if props := resp.StreamingLocatorProperties; props != nil {
+		d.Set("asset_name", props.AssetName)
+		d.Set("streaming_policy_name", props.StreamingPolicyName)
+
+		if props.StreamingLocatorID != nil {
+			d.Set("streaming_locator_id", props.StreamingLocatorID.String())
+		}
+	}
+
+	urls, err := client.ListPaths(ctx, resourceGroup, accountName, name)
+	if err != nil {
+		return fmt.Errorf("Error listing paths for Media Streaming Locator %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+	d.Set("streaming_urls", flattenAzureRmMediaStreamingLocatorUrls(urls.StreamingPaths))
+
+	return nil
+}
+
+func resourceArmMediaStreamingLocatorDelete(d *schema.ResourceData, meta interface{}) error {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	resourceGroup := id.ResourceGroup
+	accountName := id.Path["mediaservices"]
+	name := id.Path["streamingLocators"]
+
+	client := meta.(*ArmClient).mediaStreamingLocatorsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resp, err := client.Delete(ctx, resourceGroup, accountName, name)
+	if err != nil {
+		if response.WasNotFound(resp) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Media Streaming Locator %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func flattenAzureRmMediaStreamingLocatorUrls(paths *[]media.StreamingPath) []interface{} {
+	if paths == nil {
+		return []interface{}{}
+	}
+
+	urls := make([]interface{}, 0)
+	for _, path := range *paths {
+		if path.Paths == nil {
+			continue
+		}
+
+		for _, p := range *path.Paths {
+			urls = append(urls, p)
+		}
+	}
+
+	return urls
+}
+
+func resourceArmMediaStreamingLocatorImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	if _, ok := id.Path["mediaservices"]; !ok {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: expected segment 'mediaservices'", d.Id())
+	}
+
+	if _, ok := id.Path["streamingLocators"]; !ok {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: expected segment 'streamingLocators'", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,147 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/mediaservices/mgmt/2018-07-01/media"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmMediaTransform() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmMediaTransformCreateUpdate,
+		Read:   resourceArmMediaTransformRead,
+		Update: resourceArmMediaTransformCreateUpdate,
+		Delete: resourceArmMediaTransformDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceArmMediaTransformImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"media_services_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceArmMediaTransformCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	resourceGroup := d.Get("resource_group_name").(string)
+	accountName := d.Get("media_services_account_name").(string)
+	client := meta.(*ArmClient).mediaTransformsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+
+	parameters := media.Transform{
+		TransformProperties: &media.TransformProperties{
+			Description: utils.String(d.Get("description").(string)),
+		},
+	}
+
+	transform, err := client.CreateOrUpdate(ctx, resourceGroup, accountName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating Media Transform %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	d.SetId(*transform.ID)
+
+	return resourceArmMediaTransformRead(d, meta)
+}
+
+func resourceArmMediaTransformRead(d *schema.ResourceData, meta interface{}) error {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	resourceGroup := id.ResourceGroup
+	accountName := id.Path["mediaservices"]
+	name := id.Path["transforms"]
+
+	client := meta.(*ArmClient).mediaTransformsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resp, err := client.Get(ctx, resourceGroup, accountName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Media Transform %q (Media Services Account %q / Resource Group %q) was not found - removing from state", name, accountName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Media Transform %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("media_services_account_name", accountName)
+
+	if props := resp.TransformProperties; props != nil {
+		d.Set("description", props.Description)
+	}
+
+	return nil
+}
+
+func resourceArmMediaTransformDelete(d *schema.ResourceData, meta interface{}) error {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	resourceGroup := id.ResourceGroup
+	accountName := id.Path["mediaservices"]
+	name := id.Path["transforms"]
+
+	client := meta.(*ArmClient).mediaTransformsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resp, err := client.Delete(ctx, resourceGroup, accountName, name)
+	if err != nil {
+		if response.WasNotFound(resp) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Media Transform %q (Media Services Account %q / Resource Group %q): %+v", name, accountName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func resourceArmMediaTransformImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: %+v", d.Id(), err)
+	}
+
+	if _, ok := id.Path["mediaservices"]; !ok {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: expected segment 'mediaservices'", d.Id())
+	}
+
+	if _, ok := id.Path["transforms"]; !ok {
+		return nil, fmt.Errorf("Error parsing Azure Resource ID %q: expected segment 'transforms'", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}